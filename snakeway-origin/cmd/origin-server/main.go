@@ -6,18 +6,18 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"upstream/server"
 
 	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	googlegrpc "google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
@@ -32,34 +32,94 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	listeners, err := server.NewListeners()
+	if err != nil {
+		log.Fatalf("failed to set up listeners: %v", err)
+	}
+
+	rt := server.NewRuntime(server.ShutdownGrace())
+
 	// -------------------------------------------------------------------------
 	// TLS config
 	// -------------------------------------------------------------------------
-	tlsCfg, err := server.NewTLSConfig(server.TLSOptions{
-		CertFile: cfg.CertFile,
-		KeyFile:  cfg.KeyFile,
-	})
+	certProvider, err := server.NewCertProvider(cfg)
+	if err != nil {
+		log.Fatalf("cert provider error: %v", err)
+	}
+	tlsCfg := server.NewTLSConfig(certProvider)
+
+	// -------------------------------------------------------------------------
+	// User store
+	// -------------------------------------------------------------------------
+	userStore, err := server.NewUserStore(cfg)
 	if err != nil {
-		log.Fatalf("TLS config error: %v", err)
+		log.Fatalf("user store error: %v", err)
 	}
 
+	// -------------------------------------------------------------------------
+	// gRPC over UDS (plaintext)
+	// -------------------------------------------------------------------------
+	// Local sidecars talk to UserService here without TLS overhead. The
+	// same server (minus its UDS listener) also backs gRPC/gRPC-Web
+	// transcoding on the shared HTTP handler below, since ServeHTTP mode
+	// doesn't consult a server's transport credentials.
+	grpcSock := fmt.Sprintf("/tmp/snakeway-grpc-%d.sock", cfg.InstanceId)
+	_ = os.Remove(grpcSock)
+
+	grpcUdsLis, err := listeners.Listen("grpc-uds", "unix", grpcSock)
+	if err != nil {
+		log.Fatalf("failed to listen on gRPC UDS %s: %v", grpcSock, err)
+	}
+	_ = os.Chmod(grpcSock, 0660)
+
+	grpcServerPlain := googlegrpc.NewServer(
+		googlegrpc.ChainUnaryInterceptor(server.UnaryMetricsInterceptor, server.UnaryLoggingInterceptor),
+		googlegrpc.ChainStreamInterceptor(server.StreamMetricsInterceptor, server.StreamLoggingInterceptor),
+	)
+	server.RegisterUserService(grpcServerPlain, userStore)
+	rt.TrackGRPCServer(grpcServerPlain)
+
+	log.Printf("Listening gRPC (plaintext) on UDS %s\n", grpcSock)
+	go func() {
+		if err := grpcServerPlain.Serve(grpcUdsLis); err != nil {
+			log.Fatalf("gRPC UDS server failed: %v", err)
+		}
+	}()
+
+	grpcWebConn, err := googlegrpc.NewClient("unix://"+grpcSock, googlegrpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to dial gRPC-Web backend: %v", err)
+	}
+	grpcWebBridge := server.NewGRPCWebBridge(grpcWebConn)
+
 	// -------------------------------------------------------------------------
 	// HTTP handler
 	// -------------------------------------------------------------------------
-	handler := server.NewHTTPHandler()
+	// Wrapped with transcoding so the same mux also answers native gRPC
+	// (over h2c below, or TLS-terminated HTTP/2 on the HTTPS listeners)
+	// and browser gRPC-Web calls, alongside REST/WS.
+	handler := server.WithGRPCTranscoding(server.NewHTTPHandler(rt, userStore), grpcServerPlain, grpcWebBridge)
 
 	// -------------------------------------------------------------------------
 	// HTTP over TCP
 	// -------------------------------------------------------------------------
 	httpAddr := fmt.Sprintf(":%d", cfg.Port)
+	httpLis, err := listeners.Listen("http", "tcp", httpAddr)
+	if err != nil {
+		log.Fatalf("failed to listen on HTTP %s: %v", httpAddr, err)
+	}
+
 	httpSrvTCP := &http.Server{
-		Addr:    httpAddr,
-		Handler: handler,
+		Addr: httpAddr,
+		// h2c allows cleartext HTTP/2 so native gRPC clients can reach
+		// the transcoding path above without TLS.
+		Handler: h2c.NewHandler(handler, &http2.Server{}),
 	}
+	rt.TrackHTTPServer(httpSrvTCP)
 
 	go func() {
 		log.Printf("Starting HTTP + WS on %s\n", httpAddr)
-		if err := httpSrvTCP.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := httpSrvTCP.Serve(httpLis); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("HTTP TCP server failed: %v", err)
 		}
 	}()
@@ -68,6 +128,11 @@ func main() {
 	// HTTPS over TCP (TLS + h2)
 	// -------------------------------------------------------------------------
 	httpsAddr := fmt.Sprintf(":%d", cfg.Port+443)
+	httpsLis, err := listeners.Listen("https", "tcp", httpsAddr)
+	if err != nil {
+		log.Fatalf("failed to listen on HTTPS %s: %v", httpsAddr, err)
+	}
+
 	httpsSrvTCP := &http.Server{
 		Addr:      httpsAddr,
 		Handler:   handler,
@@ -77,10 +142,11 @@ func main() {
 	if err := http2.ConfigureServer(httpsSrvTCP, &http2.Server{}); err != nil {
 		log.Fatalf("failed to configure http2 (TCP): %v", err)
 	}
+	rt.TrackHTTPServer(httpsSrvTCP)
 
 	go func() {
 		log.Printf("Starting HTTPS + WSS on %s\n", httpsAddr)
-		if err := httpsSrvTCP.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+		if err := httpsSrvTCP.ServeTLS(httpsLis, "", ""); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("HTTPS TCP server failed: %v", err)
 		}
 	}()
@@ -91,7 +157,7 @@ func main() {
 	httpSock := fmt.Sprintf("/tmp/snakeway-http-%d.sock", cfg.InstanceId)
 	_ = os.Remove(httpSock)
 
-	httpUdsLis, err := net.Listen("unix", httpSock)
+	httpUdsLis, err := listeners.Listen("http-uds", "unix", httpSock)
 	if err != nil {
 		log.Fatalf("failed to listen on HTTP UDS %s: %v", httpSock, err)
 	}
@@ -100,6 +166,7 @@ func main() {
 	httpSrvUDS := &http.Server{
 		Handler: handler,
 	}
+	rt.TrackHTTPServer(httpSrvUDS)
 
 	log.Printf("Listening HTTP + WS on UDS %s\n", httpSock)
 	go func() {
@@ -114,7 +181,7 @@ func main() {
 	httpsSock := fmt.Sprintf("/tmp/snakeway-https-%d.sock", cfg.InstanceId)
 	_ = os.Remove(httpsSock)
 
-	httpsUdsLis, err := net.Listen("unix", httpsSock)
+	httpsUdsLis, err := listeners.Listen("https-uds", "unix", httpsSock)
 	if err != nil {
 		log.Fatalf("failed to listen on HTTPS UDS %s: %v", httpsSock, err)
 	}
@@ -130,6 +197,7 @@ func main() {
 	if err := http2.ConfigureServer(httpsSrvUDS, &http2.Server{}); err != nil {
 		log.Fatalf("failed to configure http2 (UDS): %v", err)
 	}
+	rt.TrackHTTPServer(httpsSrvUDS)
 
 	log.Printf("Listening HTTPS + WSS on UDS %s\n", httpsSock)
 	go func() {
@@ -142,15 +210,18 @@ func main() {
 	// gRPC over TCP (TLS, h2)
 	// -------------------------------------------------------------------------
 	grpcAddr := fmt.Sprintf(":%d", cfg.Port+2051)
-	grpcLis, err := net.Listen("tcp", grpcAddr)
+	grpcLis, err := listeners.Listen("grpc", "tcp", grpcAddr)
 	if err != nil {
 		log.Fatalf("failed to listen on %s: %v", grpcAddr, err)
 	}
 
 	grpcServer := googlegrpc.NewServer(
 		googlegrpc.Creds(credentials.NewTLS(tlsCfg)),
+		googlegrpc.ChainUnaryInterceptor(server.UnaryMetricsInterceptor, server.UnaryLoggingInterceptor),
+		googlegrpc.ChainStreamInterceptor(server.StreamMetricsInterceptor, server.StreamLoggingInterceptor),
 	)
-	server.RegisterUserService(grpcServer)
+	server.RegisterUserService(grpcServer, userStore)
+	rt.TrackGRPCServer(grpcServer)
 
 	go func() {
 		log.Printf("Starting gRPC (TLS, h2) on %s\n", grpcAddr)
@@ -165,12 +236,8 @@ func main() {
 	<-ctx.Done()
 	log.Println("shutting down origin-server")
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), server.ShutdownGrace())
 	defer cancel()
 
-	_ = httpSrvTCP.Shutdown(shutdownCtx)
-	_ = httpsSrvTCP.Shutdown(shutdownCtx)
-	_ = httpSrvUDS.Shutdown(shutdownCtx)
-	_ = httpsSrvUDS.Shutdown(shutdownCtx)
-	grpcServer.GracefulStop()
+	_ = rt.Shutdown(shutdownCtx)
 }
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"upstream/server"
 
 	"golang.org/x/net/http2"
@@ -22,66 +26,82 @@ func main() {
 
 	flag.Parse()
 
-	tlsCfg, err := server.NewTLSConfig(server.TLSOptions{
-		CertFile: cfg.CertFile,
-		KeyFile:  cfg.KeyFile,
-	})
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	certProvider, err := server.NewCertProvider(cfg)
+	if err != nil {
+		log.Fatalf("cert provider error: %v", err)
+	}
+	tlsCfg := server.NewTLSConfig(certProvider)
+
+	userStore, err := server.NewUserStore(cfg)
 	if err != nil {
-		log.Fatalf("TLS config error: %v", err)
+		log.Fatalf("user store error: %v", err)
 	}
 
-	httpHandler := server.NewHTTPHandler()
+	rt := server.NewRuntime(server.ShutdownGrace())
+	httpHandler := server.NewHTTPHandler(rt, userStore)
 
 	httpAddr := fmt.Sprintf(":%d", cfg.Port)
 	httpsAddr := fmt.Sprintf(":%d", cfg.Port+443)
 	grpcAddr := fmt.Sprintf(":%d", cfg.Port+2051)
 
 	// Start HTTP and WS Server (unencrypted).
+	httpServer := &http.Server{
+		Addr:    httpAddr,
+		Handler: httpHandler,
+	}
+	rt.TrackHTTPServer(httpServer)
 	go func() {
 		log.Printf("Starting HTTP + WS on %s\n", httpAddr)
-		if err := http.ListenAndServe(httpAddr, httpHandler); err != nil {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("HTTP server failed: %v", err)
 		}
 	}()
 
 	// Start HTTPS and WSS Server (TLS).
+	httpsServer := &http.Server{
+		Addr:      httpsAddr,
+		Handler:   httpHandler,
+		TLSConfig: tlsCfg,
+	}
+	// Enable HTTP/2 support.
+	if err := http2.ConfigureServer(httpsServer, &http2.Server{}); err != nil {
+		log.Fatalf("failed to configure http2: %v", err)
+	}
+	rt.TrackHTTPServer(httpsServer)
 	go func() {
-		httpsServer := &http.Server{
-			Addr:      httpsAddr,
-			Handler:   httpHandler,
-			TLSConfig: tlsCfg,
-		}
-
-		// Enable HTTP/2 support.
-		if err := http2.ConfigureServer(httpsServer, &http2.Server{}); err != nil {
-			log.Fatalf("failed to configure http2: %v", err)
-		}
-
 		log.Printf("Starting HTTPS + WSS on %s\n", httpsAddr)
-		if err := httpsServer.ListenAndServeTLS("", ""); err != nil {
+		if err := httpsServer.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("HTTPS httpsServer failed: %v", err)
 		}
 	}()
 
 	// Start gRPC Server (TLS, h2).
+	grpcServer := googlegrpc.NewServer(
+		googlegrpc.Creds(credentials.NewTLS(tlsCfg)),
+		googlegrpc.ChainUnaryInterceptor(server.UnaryMetricsInterceptor, server.UnaryLoggingInterceptor),
+		googlegrpc.ChainStreamInterceptor(server.StreamMetricsInterceptor, server.StreamLoggingInterceptor),
+	)
+	server.RegisterUserService(grpcServer, userStore)
+	rt.TrackGRPCServer(grpcServer)
 	go func() {
 		lis, err := net.Listen("tcp", grpcAddr)
 		if err != nil {
 			log.Fatalf("failed to listen on %s: %v", grpcAddr, err)
 		}
 
-		creds := credentials.NewTLS(tlsCfg)
-		grpcServer := googlegrpc.NewServer(googlegrpc.Creds(creds))
-
-		// Register the manually defined gRPC service
-		server.RegisterUserService(grpcServer)
-
 		log.Printf("Starting gRPC (TLS, h2) on %s\n", grpcAddr)
 		if err := grpcServer.Serve(lis); err != nil {
 			log.Fatalf("gRPC server failed: %v", err)
 		}
 	}()
 
-	// Block forever to keep the goroutines running.
-	select {}
+	<-ctx.Done()
+	log.Println("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), server.ShutdownGrace())
+	defer cancel()
+	_ = rt.Shutdown(shutdownCtx)
 }
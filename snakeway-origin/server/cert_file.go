@@ -0,0 +1,95 @@
+package server
+
+import (
+	"crypto/tls"
+	"log"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// FileCertProvider loads a certificate/key pair from disk and reloads it
+// whenever either file changes, so operators can rotate certificates
+// in place without restarting the process.
+type FileCertProvider struct {
+	certFile, keyFile string
+	cert              atomic.Pointer[tls.Certificate]
+	watcher           *fsnotify.Watcher
+}
+
+// NewFileCertProvider loads certFile/keyFile and starts watching them for
+// changes. Call Close when the provider is no longer needed.
+func NewFileCertProvider(certFile, keyFile string) (*FileCertProvider, error) {
+	p := &FileCertProvider{certFile: certFile, keyFile: keyFile}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(certFile); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Add(keyFile); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	p.watcher = w
+
+	go p.watch()
+	return p, nil
+}
+
+func (p *FileCertProvider) reload() error {
+	cert, err := tls.LoadX509KeyPair(p.certFile, p.keyFile)
+	if err != nil {
+		return err
+	}
+	p.cert.Store(&cert)
+	return nil
+}
+
+func (p *FileCertProvider) watch() {
+	for {
+		select {
+		case event, ok := <-p.watcher.Events:
+			if !ok {
+				return
+			}
+			// Atomic cert rotation commonly replaces the file via a
+			// rename, which drops fsnotify's watch on the old inode;
+			// re-add both paths before reloading so rotation keeps
+			// working after the first swap.
+			_ = p.watcher.Add(p.certFile)
+			_ = p.watcher.Add(p.keyFile)
+
+			if err := p.reload(); err != nil {
+				log.Printf("cert reload after %s: %v", event, err)
+				continue
+			}
+			log.Printf("reloaded TLS certificate from %s", p.certFile)
+
+		case err, ok := <-p.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("cert watcher error: %v", err)
+		}
+	}
+}
+
+// GetCertificate implements CertProvider.
+func (p *FileCertProvider) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return p.cert.Load(), nil
+}
+
+// Close stops watching the underlying files for changes.
+func (p *FileCertProvider) Close() error {
+	if p.watcher == nil {
+		return nil
+	}
+	return p.watcher.Close()
+}
@@ -0,0 +1,19 @@
+package server
+
+import "crypto/tls"
+
+// MemoryCertProvider serves a single in-memory certificate. It exists for
+// tests, where loading real files or running ACME isn't practical.
+type MemoryCertProvider struct {
+	cert *tls.Certificate
+}
+
+// NewMemoryCertProvider returns a provider that always serves cert.
+func NewMemoryCertProvider(cert tls.Certificate) *MemoryCertProvider {
+	return &MemoryCertProvider{cert: &cert}
+}
+
+// GetCertificate implements CertProvider.
+func (p *MemoryCertProvider) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return p.cert, nil
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
@@ -11,14 +12,32 @@ type Config struct {
 	CertFile   string
 	KeyFile    string
 	InstanceId int
+
+	// TLSProvider selects the server.CertProvider implementation: "file"
+	// (the default) or "acme".
+	TLSProvider  string
+	ACMEHosts    []string
+	ACMECacheDir string
+	ACMEEmail    string
+
+	// UserStoreKind selects the server.UserStore implementation: "memory"
+	// (the default) or "bbolt".
+	UserStoreKind string
+	UserStorePath string
 }
 
 func LoadConfig() Config {
 	return Config{
-		Port:       getenvInt("PORT", 3000),
-		CertFile:   getenvStr("TLS_CERT_FILE", "./data/certs/server.pem"),
-		KeyFile:    getenvStr("TLS_KEY_FILE", "./data/certs/server.key"),
-		InstanceId: getenvInt("INSTANCE_ID", 0),
+		Port:          getenvInt("PORT", 3000),
+		CertFile:      getenvStr("TLS_CERT_FILE", "./data/certs/server.pem"),
+		KeyFile:       getenvStr("TLS_KEY_FILE", "./data/certs/server.key"),
+		InstanceId:    getenvInt("INSTANCE_ID", 0),
+		TLSProvider:   getenvStr("TLS_PROVIDER", "file"),
+		ACMEHosts:     getenvList("ACME_HOSTS", nil),
+		ACMECacheDir:  getenvStr("ACME_CACHE_DIR", "./data/certs/acme-cache"),
+		ACMEEmail:     getenvStr("ACME_EMAIL", ""),
+		UserStoreKind: getenvStr("USER_STORE", "memory"),
+		UserStorePath: getenvStr("USER_STORE_PATH", "./data/users.db"),
 	}
 }
 
@@ -43,3 +62,18 @@ func getenvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getenvList(key string, fallback []string) []string {
+	v, ok := getenv(key)
+	if !ok || v == "" {
+		return fallback
+	}
+
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
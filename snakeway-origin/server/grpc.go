@@ -2,21 +2,91 @@ package server
 
 import (
 	"context"
+	"errors"
 
 	pb "upstream/userspb"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
-// UserService implements the generated gRPC interface.
+// UserService implements pb.UserServiceServer against a pluggable
+// UserStore.
 type UserService struct {
 	pb.UnimplementedUserServiceServer
+	store UserStore
 }
 
 func (s *UserService) GetUser(ctx context.Context, req *pb.UserRequest) (*pb.UserReply, error) {
-	return &pb.UserReply{Id: req.Id}, nil
+	u, err := s.store.Get(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user %q not found", req.Id)
+	}
+	return userToProto(u), nil
 }
 
-func RegisterUserService(s *grpc.Server) {
-	pb.RegisterUserServiceServer(s, &UserService{})
+func (s *UserService) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.UserReply, error) {
+	u, err := s.store.Create(User{Name: req.Name, Email: req.Email})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create user: %v", err)
+	}
+	return userToProto(u), nil
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UserReply, error) {
+	u, err := s.store.Update(User{Id: req.Id, Name: req.Name, Email: req.Email})
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user %q not found", req.Id)
+	}
+	return userToProto(u), nil
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, req *pb.UserRequest) (*pb.DeleteUserReply, error) {
+	if err := s.store.Delete(req.Id); err != nil {
+		return nil, status.Errorf(codes.NotFound, "user %q not found", req.Id)
+	}
+	return &pb.DeleteUserReply{Deleted: true}, nil
+}
+
+func (s *UserService) ListUsers(req *pb.ListUsersRequest, stream pb.UserService_ListUsersServer) error {
+	users, nextPageToken, err := s.store.List(int(req.PageSize), req.PageToken)
+	if errors.Is(err, ErrInvalidPageToken) {
+		return status.Errorf(codes.InvalidArgument, "list users: %v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "list users: %v", err)
+	}
+
+	for i, u := range users {
+		resp := &pb.ListUsersResponse{User: userToProto(u)}
+		if i == len(users)-1 {
+			resp.NextPageToken = nextPageToken
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func userToProto(u User) *pb.UserReply {
+	return &pb.UserReply{Id: u.Id, Name: u.Name, Email: u.Email}
+}
+
+// RegisterUserService registers UserService (backed by store) on s,
+// alongside gRPC reflection and health checking so tools like grpcurl
+// work out of the box and Kubernetes liveness/readiness probes can query
+// health.
+func RegisterUserService(s *grpc.Server, store UserStore) {
+	pb.RegisterUserServiceServer(s, &UserService{store: store})
+
+	reflection.Register(s)
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthSrv)
 }
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey is the gRPC metadata key carrying the request ID,
+// mirroring the HTTP X-Request-ID header.
+const requestIDMetadataKey = "x-request-id"
+
+// requestIDFromIncoming reads the request ID from ctx's incoming
+// metadata, generating one if the caller didn't send one, and returns a
+// context that carries it both via RequestIDFromContext and as outgoing
+// metadata for any calls the handler makes onward.
+func requestIDFromIncoming(ctx context.Context) (context.Context, string) {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(requestIDMetadataKey); len(vals) > 0 {
+			id = vals[0]
+		}
+	}
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, id)
+	return ctx, id
+}
+
+// UnaryLoggingInterceptor stamps a request ID onto the RPC context
+// (generating one if the caller didn't send one) and emits one structured
+// log line per call via log/slog.
+func UnaryLoggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	ctx, id := requestIDFromIncoming(ctx)
+
+	resp, err := handler(ctx, req)
+
+	slog.Info("grpc request",
+		"method", info.FullMethod,
+		"code", status.Code(err).String(),
+		"duration", time.Since(start),
+		"request_id", id,
+	)
+	return resp, err
+}
+
+// StreamLoggingInterceptor is the streaming-RPC counterpart of
+// UnaryLoggingInterceptor.
+func StreamLoggingInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, id := requestIDFromIncoming(ss.Context())
+
+	err := handler(srv, &requestIDServerStream{ServerStream: ss, ctx: ctx})
+
+	slog.Info("grpc stream",
+		"method", info.FullMethod,
+		"code", status.Code(err).String(),
+		"duration", time.Since(start),
+		"request_id", id,
+	)
+	return err
+}
+
+// requestIDServerStream overrides ServerStream.Context so handlers observe
+// the request-ID-stamped context produced by requestIDFromIncoming.
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDServerStream) Context() context.Context { return s.ctx }
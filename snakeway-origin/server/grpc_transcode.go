@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// IsGRPCContentType reports whether r is a native gRPC call (served over
+// h2c or TLS-terminated HTTP/2), as opposed to REST/WS traffic or a
+// browser gRPC-Web request.
+func IsGRPCContentType(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	return strings.HasPrefix(ct, "application/grpc") && !strings.HasPrefix(ct, "application/grpc-web")
+}
+
+// IsGRPCWebContentType reports whether r is a gRPC-Web request from a
+// browser client, per the application/grpc-web(+proto|-text) content types.
+func IsGRPCWebContentType(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc-web")
+}
+
+// WithGRPCTranscoding mounts grpcSrv and web onto next so a single HTTP(S)
+// port can serve REST/WS alongside native gRPC and browser gRPC-Web calls,
+// dispatching on Content-Type. grpcSrv is served via its ServeHTTP method
+// rather than Serve, so it should be built without transport credentials
+// of its own: for requests reaching this handler, transport security is
+// whatever the enclosing http.Server already terminated (TLS, or none over
+// h2c).
+func WithGRPCTranscoding(next http.Handler, grpcSrv *grpc.Server, web http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case IsGRPCContentType(r):
+			grpcSrv.ServeHTTP(w, r)
+		case IsGRPCWebContentType(r):
+			web.ServeHTTP(w, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
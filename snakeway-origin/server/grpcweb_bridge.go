@@ -0,0 +1,152 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcWebTrailerFlag marks a gRPC-Web length-prefixed frame as carrying
+// trailers rather than a message, per the gRPC-Web wire format.
+const grpcWebTrailerFlag = 0x80
+
+// rawCodec is a grpc.Codec that passes message bytes through unmodified,
+// letting NewGRPCWebBridge proxy an already-framed gRPC-Web payload without
+// decoding it into a concrete proto.Message.
+type rawCodec struct{}
+
+func (rawCodec) Name() string { return "proto" }
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	switch b := v.(type) {
+	case []byte:
+		return b, nil
+	case *[]byte:
+		return *b, nil
+	default:
+		return nil, fmt.Errorf("grpcweb: rawCodec.Marshal: unsupported type %T", v)
+	}
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("grpcweb: rawCodec.Unmarshal: unsupported type %T", v)
+	}
+	*b = append([]byte(nil), data...)
+	return nil
+}
+
+// NewGRPCWebBridge returns an http.Handler that translates gRPC-Web
+// requests (Content-Type application/grpc-web, +proto, or base64-encoded
+// -text) into plain unary gRPC calls over cc, so browser JS clients can
+// call UserService's unary methods without a separate Envoy proxy.
+// Streaming methods (ListUsers) aren't supported over this bridge.
+func NewGRPCWebBridge(cc *grpc.ClientConn) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		textFramed := strings.Contains(r.Header.Get("Content-Type"), "grpc-web-text")
+
+		body := io.Reader(r.Body)
+		if textFramed {
+			body = base64.NewDecoder(base64.StdEncoding, body)
+		}
+
+		reqMsg, err := readGRPCWebFrame(body)
+		if err != nil {
+			http.Error(w, "grpcweb: bad request frame: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := grpcWebOutgoingContext(r.Context(), r.Header)
+
+		var respMsg []byte
+		var trailer metadata.MD
+		callErr := cc.Invoke(ctx, r.URL.Path, reqMsg, &respMsg, grpc.ForceCodec(rawCodec{}), grpc.Trailer(&trailer))
+
+		var out bytes.Buffer
+		if callErr == nil {
+			writeGRPCWebFrame(&out, 0, respMsg)
+		}
+		writeGRPCWebFrame(&out, grpcWebTrailerFlag, grpcWebTrailerBytes(callErr, trailer))
+
+		contentType := "application/grpc-web+proto"
+		payload := out.Bytes()
+		if textFramed {
+			contentType = "application/grpc-web-text"
+			payload = []byte(base64.StdEncoding.EncodeToString(payload))
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(payload)
+	})
+}
+
+// readGRPCWebFrame reads a single gRPC-Web length-prefixed message frame
+// (a 1-byte flags field, a 4-byte big-endian length, then the message) and
+// returns the message bytes.
+func readGRPCWebFrame(r io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// writeGRPCWebFrame writes a single length-prefixed gRPC-Web frame; flags
+// is 0 for a message frame or grpcWebTrailerFlag for the trailers-in-body
+// frame that ends every gRPC-Web response.
+func writeGRPCWebFrame(w io.Writer, flags byte, msg []byte) {
+	var header [5]byte
+	header[0] = flags
+	binary.BigEndian.PutUint32(header[1:], uint32(len(msg)))
+	_, _ = w.Write(header[:])
+	_, _ = w.Write(msg)
+}
+
+// grpcWebTrailerBytes renders the trailers-in-body frame gRPC-Web expects
+// at the end of a response: the call's grpc-status/grpc-message, plus any
+// trailer metadata the RPC set.
+func grpcWebTrailerBytes(callErr error, trailer metadata.MD) []byte {
+	st := status.Convert(callErr)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "grpc-status: %d\r\n", st.Code())
+	if msg := st.Message(); msg != "" {
+		fmt.Fprintf(&b, "grpc-message: %s\r\n", msg)
+	}
+	for k, vs := range trailer {
+		for _, v := range vs {
+			fmt.Fprintf(&b, "%s: %s\r\n", k, v)
+		}
+	}
+	return []byte(b.String())
+}
+
+// grpcWebOutgoingContext copies browser-sent headers (other than
+// hop-by-hop and framing ones) into outgoing gRPC metadata, so things like
+// X-Request-ID still propagate through the bridge.
+func grpcWebOutgoingContext(ctx context.Context, h http.Header) context.Context {
+	md := metadata.MD{}
+	for k, vs := range h {
+		lk := strings.ToLower(k)
+		switch lk {
+		case "content-type", "content-length", "x-grpc-web", "connection", "accept", "accept-encoding":
+			continue
+		}
+		md[lk] = append(md[lk], vs...)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCWebFrameRoundTrip(t *testing.T) {
+	cases := []struct {
+		name  string
+		flags byte
+		msg   []byte
+	}{
+		{"empty message", 0, nil},
+		{"message", 0, []byte("hello")},
+		{"trailer flag", grpcWebTrailerFlag, []byte("grpc-status: 0\r\n")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeGRPCWebFrame(&buf, tc.flags, tc.msg)
+
+			if got := buf.Bytes()[0]; got != tc.flags {
+				t.Fatalf("frame flags byte = %#x, want %#x", got, tc.flags)
+			}
+
+			got, err := readGRPCWebFrame(&buf)
+			if err != nil {
+				t.Fatalf("readGRPCWebFrame: %v", err)
+			}
+			if !bytes.Equal(got, tc.msg) {
+				t.Fatalf("readGRPCWebFrame = %q, want %q", got, tc.msg)
+			}
+		})
+	}
+}
+
+func TestGRPCWebResponseFramingMessageThenTrailers(t *testing.T) {
+	var out bytes.Buffer
+	msg := []byte("resp-bytes")
+	writeGRPCWebFrame(&out, 0, msg)
+	writeGRPCWebFrame(&out, grpcWebTrailerFlag, grpcWebTrailerBytes(nil, metadata.MD{"x-trace": []string{"abc"}}))
+
+	gotMsg, err := readGRPCWebFrame(&out)
+	if err != nil {
+		t.Fatalf("reading message frame: %v", err)
+	}
+	if !bytes.Equal(gotMsg, msg) {
+		t.Fatalf("message frame = %q, want %q", gotMsg, msg)
+	}
+
+	trailerHeader := make([]byte, 5)
+	if _, err := io.ReadFull(&out, trailerHeader); err != nil {
+		t.Fatalf("reading trailer frame header: %v", err)
+	}
+	if trailerHeader[0] != grpcWebTrailerFlag {
+		t.Fatalf("trailer frame flags = %#x, want %#x", trailerHeader[0], grpcWebTrailerFlag)
+	}
+
+	gotTrailer := make([]byte, out.Len())
+	if _, err := io.ReadFull(&out, gotTrailer); err != nil {
+		t.Fatalf("reading trailer frame body: %v", err)
+	}
+	want := "grpc-status: 0\r\nx-trace: abc\r\n"
+	if string(gotTrailer) != want {
+		t.Fatalf("trailer body = %q, want %q", gotTrailer, want)
+	}
+}
+
+func TestGRPCWebTrailerBytesReflectsError(t *testing.T) {
+	err := status.Error(codes.NotFound, "user not found")
+	got := string(grpcWebTrailerBytes(err, nil))
+
+	want := "grpc-status: 5\r\ngrpc-message: user not found\r\n"
+	if got != want {
+		t.Fatalf("grpcWebTrailerBytes(%v) = %q, want %q", err, got, want)
+	}
+}
+
+func TestReadGRPCWebFrameTruncated(t *testing.T) {
+	// A frame header claiming more message bytes than are actually present
+	// must surface as an error, not silently return a short message.
+	buf := bytes.NewBuffer([]byte{0, 0, 0, 0, 10, 'a', 'b'})
+	if _, err := readGRPCWebFrame(buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("readGRPCWebFrame with truncated body: err = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
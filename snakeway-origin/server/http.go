@@ -0,0 +1,153 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// listUsersResponse is the JSON body for a GET /api/users/ list request.
+type listUsersResponse struct {
+	Users         []User `json:"users"`
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+// NewHTTPHandler returns a new ServeMux configured with HTTP, WebSocket, and
+// user CRUD routes, wrapped in the standard middleware stack (request ID,
+// structured logging, Prometheus metrics). When rt is non-nil, WebSocket
+// connections are tracked on it so Runtime.Shutdown can drain them; passing
+// nil is fine for tests and other callers that don't need coordinated
+// shutdown. store backs the /api/users/ routes, sharing records with the
+// gRPC UserService registered via RegisterUserService.
+func NewHTTPHandler(rt *Runtime, store UserStore) http.Handler {
+	mux := http.NewServeMux()
+
+	// Root handler
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("hello http\n"))
+	})
+
+	// User API handler
+	mux.HandleFunc("/api/users/", func(w http.ResponseWriter, r *http.Request) {
+		handleUsers(store, w, r)
+	})
+
+	// WebSocket echo handler
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+
+		websocketConnections.Inc()
+		defer websocketConnections.Dec()
+
+		if rt != nil {
+			defer rt.TrackWS(c)()
+		}
+
+		for {
+			mt, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			_ = c.WriteMessage(mt, append([]byte("echo: "), msg...))
+		}
+	})
+
+	// Metrics endpoint
+	mux.Handle("/metrics", MetricsHandler())
+
+	return Chain(mux, RequestIDMiddleware, LoggingMiddleware, MetricsMiddleware)
+}
+
+// handleUsers dispatches /api/users/ requests to store by method: GET
+// lists users (or fetches one, when an id is given), POST creates, PUT
+// updates, and DELETE removes.
+func handleUsers(store UserStore, w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Path[len("/api/users/"):]
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		if id == "" {
+			pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+			users, nextPageToken, err := store.List(pageSize, r.URL.Query().Get("page_token"))
+			if errors.Is(err, ErrInvalidPageToken) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(listUsersResponse{Users: users, NextPageToken: nextPageToken})
+			return
+		}
+
+		u, err := store.Get(id)
+		if errors.Is(err, ErrUserNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(u)
+
+	case http.MethodPost:
+		var u User
+		if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created, err := store.Create(u)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(created)
+
+	case http.MethodPut:
+		var u User
+		if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		u.Id = id
+
+		updated, err := store.Update(u)
+		if errors.Is(err, ErrUserNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(updated)
+
+	case http.MethodDelete:
+		if err := store.Delete(id); errors.Is(err, ErrUserNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, POST, PUT, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// listenFDsStart is the first file descriptor number systemd passes to a
+// socket-activated process, per the sd_listen_fds(3) protocol.
+const listenFDsStart = 3
+
+// Listeners resolves the listener for a named role (e.g. "http", "https",
+// "grpc", "http-uds", "https-uds"), preferring a file descriptor inherited
+// from the process environment over opening a fresh socket. This lets
+// origin-server be started under systemd socket activation, or handed a
+// single fd across a restart exec by a non-systemd process manager, while
+// falling back to net.Listen when nothing was inherited.
+type Listeners struct {
+	byName map[string]net.Listener
+}
+
+// NewListeners inspects LISTEN_FDS/LISTEN_PID/LISTEN_FDNAMES and adopts any
+// file descriptors systemd passed to this process. It is safe to call when
+// no fds were inherited; the returned Listeners then only serves fallback
+// listens.
+func NewListeners() (*Listeners, error) {
+	l := &Listeners{byName: map[string]net.Listener{}}
+	if err := l.adoptSystemdFDs(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Listeners) adoptSystemdFDs() error {
+	pid, ok := os.LookupEnv("LISTEN_PID")
+	if !ok || atoiOrZero(pid) != os.Getpid() {
+		return nil
+	}
+
+	count, ok := os.LookupEnv("LISTEN_FDS")
+	if !ok {
+		return nil
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n <= 0 {
+		return nil
+	}
+
+	var names []string
+	if raw, ok := os.LookupEnv("LISTEN_FDNAMES"); ok {
+		names = strings.Split(raw, ":")
+	}
+
+	for i := 0; i < n; i++ {
+		fd := listenFDsStart + i
+		name := fmt.Sprintf("fd%d", i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+
+		lis, err := listenerFromFD(fd, name)
+		if err != nil {
+			return fmt.Errorf("adopt systemd fd %d (%s): %w", fd, name, err)
+		}
+		l.byName[name] = lis
+	}
+	return nil
+}
+
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func listenerFromFD(fd int, name string) (net.Listener, error) {
+	syscall.CloseOnExec(fd)
+	f := os.NewFile(uintptr(fd), name)
+	defer f.Close()
+	return net.FileListener(f)
+}
+
+// Listen returns the listener for role: an fd inherited via systemd socket
+// activation under that name, an fd named by the SNAKEWAY_INHERIT_FD_<ROLE>
+// environment variable, or (when neither is present) the result of
+// net.Listen(network, addr).
+func (l *Listeners) Listen(role, network, addr string) (net.Listener, error) {
+	if lis, ok := l.byName[role]; ok {
+		return lis, nil
+	}
+
+	envKey := "SNAKEWAY_INHERIT_FD_" + strings.ToUpper(strings.ReplaceAll(role, "-", "_"))
+	if v, ok := os.LookupEnv(envKey); ok {
+		fd, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s=%q: %w", envKey, v, err)
+		}
+		lis, err := listenerFromFD(fd, role)
+		if err != nil {
+			return nil, fmt.Errorf("adopt %s=%d: %w", envKey, fd, err)
+		}
+		l.byName[role] = lis
+		return lis, nil
+	}
+
+	return net.Listen(network, addr)
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code
+// and byte count written, for logging and metrics middleware. It forwards
+// Hijack/Flush/Push to the embedded ResponseWriter so middleware-wrapped
+// handlers can still upgrade connections (e.g. the /ws WebSocket handler)
+// or stream responses.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Hijack lets callers behind this recorder (e.g. gorilla/websocket's
+// Upgrader) take over the connection, as http.Hijacker. A successful
+// hijack never calls WriteHeader, so seed status to 101 here - the only
+// response a hijacker in this codebase writes is a WebSocket upgrade -
+// rather than leaving it at its zero value for LoggingMiddleware and
+// MetricsMiddleware to log and count as status=0.
+func (w *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("statusRecorder: underlying %T does not support hijacking", w.ResponseWriter)
+	}
+	conn, rw, err := hj.Hijack()
+	if err == nil {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Flush forwards to the embedded ResponseWriter's Flush, as http.Flusher.
+func (w *statusRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Push forwards to the embedded ResponseWriter's Push, as http.Pusher.
+func (w *statusRecorder) Push(target string, opts *http.PushOptions) error {
+	p, ok := w.ResponseWriter.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return p.Push(target, opts)
+}
+
+// LoggingMiddleware emits one structured log line per request via
+// log/slog: method, path, status, bytes written, duration, remote
+// address, negotiated TLS version and ALPN protocol (when served over
+// TLS), and the request ID stamped by RequestIDMiddleware. It does not
+// log an HTTP/2 stream ID: net/http's http.Handler surface (what this
+// middleware wraps) never exposes one - golang.org/x/net/http2 keeps
+// stream state on its unexported Server, and neither *http.Request nor
+// http.ResponseWriter carries it out. Getting one would mean running
+// our own http2.Server with a ConnState/trace hook instead of stdlib's
+// http2.ConfigureServer, which is a bigger change than this middleware.
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r)
+
+		attrs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"bytes", rec.bytes,
+			"duration", time.Since(start),
+			"remote", r.RemoteAddr,
+			"proto", r.Proto,
+			"request_id", RequestIDFromContext(r.Context()),
+		}
+		if r.TLS != nil {
+			attrs = append(attrs,
+				"tls_version", tls.VersionName(r.TLS.Version),
+				"alpn_proto", r.TLS.NegotiatedProtocol,
+			)
+		}
+
+		slog.Info("http request", attrs...)
+	})
+}
@@ -0,0 +1,16 @@
+package server
+
+import "net/http"
+
+// Middleware wraps an http.Handler to add cross-cutting behavior (logging,
+// metrics, request ID propagation, ...).
+type Middleware func(http.Handler) http.Handler
+
+// Chain applies mws to h in order, so the first middleware in the list is
+// the outermost wrapper and sees a request first.
+func Chain(h http.Handler, mws ...Middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
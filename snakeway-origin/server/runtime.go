@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+)
+
+// DefaultShutdownGrace is used when SHUTDOWN_GRACE is unset or invalid.
+const DefaultShutdownGrace = 10 * time.Second
+
+// Runtime owns every listener-facing server in a process (HTTP, HTTPS, UDS,
+// gRPC) plus the set of live WebSocket connections, and drives a three-phase
+// shutdown across all of them, all bounded by a single shared deadline: stop
+// accepting new connections and close idle keepalive conns, then give
+// in-flight WebSocket connections a chance to close cleanly and force-close
+// any that don't, then do the same for in-flight gRPC RPCs.
+type Runtime struct {
+	grace time.Duration
+
+	mu       sync.Mutex
+	httpSrvs []*http.Server
+	grpcSrvs []*grpc.Server
+	wsConns  map[*websocket.Conn]struct{}
+	wsWG     sync.WaitGroup
+}
+
+// NewRuntime returns a Runtime whose drain phase waits up to grace before
+// forcing remaining WebSocket connections and gRPC streams closed.
+func NewRuntime(grace time.Duration) *Runtime {
+	return &Runtime{
+		grace:   grace,
+		wsConns: make(map[*websocket.Conn]struct{}),
+	}
+}
+
+// TrackHTTPServer registers an HTTP(S) server so Shutdown stops it.
+func (r *Runtime) TrackHTTPServer(s *http.Server) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.httpSrvs = append(r.httpSrvs, s)
+}
+
+// TrackGRPCServer registers a gRPC server so Shutdown gracefully stops it.
+func (r *Runtime) TrackGRPCServer(s *grpc.Server) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.grpcSrvs = append(r.grpcSrvs, s)
+}
+
+// TrackWS registers a WebSocket connection as in-flight and returns a func
+// that must be called (typically via defer) once the connection is done.
+func (r *Runtime) TrackWS(c *websocket.Conn) (forget func()) {
+	r.mu.Lock()
+	r.wsConns[c] = struct{}{}
+	r.mu.Unlock()
+	r.wsWG.Add(1)
+
+	return func() {
+		r.mu.Lock()
+		delete(r.wsConns, c)
+		r.mu.Unlock()
+		r.wsWG.Done()
+	}
+}
+
+// Shutdown drains every tracked server against a single shared deadline -
+// ctx's, or now+r.grace if ctx has none - so the three phases below don't
+// each spend the full grace period in turn. It first stops each HTTP(S)
+// server from accepting new connections and closes idle keepalive conns,
+// then sends a WebSocket "going away" close frame to every still-tracked
+// connection and waits for them to close, force-closing any still open at
+// the deadline (a client that ignores the close frame would otherwise
+// block its /ws handler in ReadMessage forever), then does the same for
+// in-flight gRPC RPCs: wait for GracefulStop, force Stop at the deadline.
+func (r *Runtime) Shutdown(ctx context.Context) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(r.grace)
+	}
+
+	r.mu.Lock()
+	httpSrvs := append([]*http.Server(nil), r.httpSrvs...)
+	grpcSrvs := append([]*grpc.Server(nil), r.grpcSrvs...)
+	r.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, s := range httpSrvs {
+		wg.Add(1)
+		go func(s *http.Server) {
+			defer wg.Done()
+			if err := s.Shutdown(ctx); err != nil {
+				log.Printf("http server shutdown: %v", err)
+			}
+		}(s)
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	wsConns := make([]*websocket.Conn, 0, len(r.wsConns))
+	for c := range r.wsConns {
+		wsConns = append(wsConns, c)
+	}
+	r.mu.Unlock()
+
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	for _, c := range wsConns {
+		_ = c.WriteControl(websocket.CloseMessage, closeMsg, deadline)
+	}
+
+	wsDone := make(chan struct{})
+	go func() {
+		r.wsWG.Wait()
+		close(wsDone)
+	}()
+
+	select {
+	case <-wsDone:
+	case <-time.After(time.Until(deadline)):
+		r.mu.Lock()
+		stragglers := make([]*websocket.Conn, 0, len(r.wsConns))
+		for c := range r.wsConns {
+			stragglers = append(stragglers, c)
+		}
+		r.mu.Unlock()
+		for _, c := range stragglers {
+			_ = c.Close()
+		}
+	}
+
+	if len(grpcSrvs) == 0 {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for _, s := range grpcSrvs {
+			s.GracefulStop()
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Until(deadline)):
+		for _, s := range grpcSrvs {
+			s.Stop()
+		}
+	}
+
+	return nil
+}
+
+// ShutdownGrace reads SHUTDOWN_GRACE (a Go duration string, e.g. "15s")
+// from the environment, falling back to DefaultShutdownGrace.
+func ShutdownGrace() time.Duration {
+	v, ok := getenv("SHUTDOWN_GRACE")
+	if !ok {
+		return DefaultShutdownGrace
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("invalid SHUTDOWN_GRACE=%q, using default %s", v, DefaultShutdownGrace)
+		return DefaultShutdownGrace
+	}
+	return d
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// CertProvider supplies the certificate for a TLS handshake. It backs
+// tls.Config.GetCertificate, so certificate material can rotate (a file
+// change, an ACME renewal) without rebuilding the listener.
+type CertProvider interface {
+	GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error)
+}
+
+// tlsConfigProvider is implemented by CertProviders that need more than
+// GetCertificate on the resulting tls.Config. ACMECertProvider is one:
+// its tls-alpn-01 challenge only completes if the handshake can negotiate
+// the "acme-tls/1" ALPN, which NewTLSConfig's own NextProtos doesn't
+// advertise.
+type tlsConfigProvider interface {
+	TLSConfig() *tls.Config
+}
+
+// NewTLSConfig returns a tls.Config that resolves certificates through
+// provider on every handshake. Share one *tls.Config (and hence one
+// provider) across every TLS-terminating surface - HTTPS over TCP, HTTPS
+// over UDS, gRPC credentials - so a single rotation event updates all of
+// them at once. When provider also implements tlsConfigProvider, its
+// TLSConfig is used as-is (with our MinVersion filled in if unset) instead
+// of building a generic one, so provider-specific requirements like ACME's
+// ALPN challenge still work.
+func NewTLSConfig(provider CertProvider) *tls.Config {
+	if p, ok := provider.(tlsConfigProvider); ok {
+		cfg := p.TLSConfig()
+		if cfg.MinVersion == 0 {
+			cfg.MinVersion = tls.VersionTLS12
+		}
+		return cfg
+	}
+
+	return &tls.Config{
+		GetCertificate: provider.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+		MinVersion:     tls.VersionTLS12,
+	}
+}
+
+// NewCertProvider builds the CertProvider selected by cfg.TLSProvider:
+// "file" (the default) watches cfg.CertFile/KeyFile for changes, "acme"
+// issues and renews certificates for cfg.ACMEHosts.
+func NewCertProvider(cfg Config) (CertProvider, error) {
+	switch cfg.TLSProvider {
+	case "", "file":
+		return NewFileCertProvider(cfg.CertFile, cfg.KeyFile)
+	case "acme":
+		return NewACMECertProvider(ACMEOptions{
+			Hosts:    cfg.ACMEHosts,
+			CacheDir: cfg.ACMECacheDir,
+			Email:    cfg.ACMEEmail,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown TLS_PROVIDER %q", cfg.TLSProvider)
+	}
+}
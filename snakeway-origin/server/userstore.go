@@ -0,0 +1,54 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUserNotFound is returned by UserStore methods when no record exists
+// for the given id.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrInvalidPageToken is returned by List when pageToken isn't a value it
+// issued (e.g. malformed, or from a different UserStore implementation).
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// User is the storage representation of a user record.
+type User struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// UserStore persists User records for UserService and the REST /api/users/
+// handler, which share one store so both surfaces stay in sync.
+type UserStore interface {
+	// Create assigns an id to u and stores it.
+	Create(u User) (User, error)
+	// Get returns the user for id, or ErrUserNotFound.
+	Get(id string) (User, error)
+	// Update replaces the user at u.Id, or returns ErrUserNotFound.
+	Update(u User) (User, error)
+	// Delete removes the user for id, or returns ErrUserNotFound.
+	Delete(id string) error
+	// List returns up to pageSize users in ascending id order (ids are
+	// assigned as increasing integers, so this is also creation order),
+	// starting after the first id greater than pageToken - not
+	// necessarily the record pageToken itself, which may have been
+	// deleted since the token was issued - plus the token to pass for
+	// the next page (empty when there isn't one).
+	List(pageSize int, pageToken string) (users []User, nextPageToken string, err error)
+}
+
+// NewUserStore builds the UserStore selected by cfg.UserStoreKind: "memory"
+// (the default) or "bbolt".
+func NewUserStore(cfg Config) (UserStore, error) {
+	switch cfg.UserStoreKind {
+	case "", "memory":
+		return NewMemoryUserStore(), nil
+	case "bbolt":
+		return NewBoltUserStore(cfg.UserStorePath)
+	default:
+		return nil, fmt.Errorf("unknown USER_STORE %q", cfg.UserStoreKind)
+	}
+}
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"go.etcd.io/bbolt"
+)
+
+var usersBucket = []byte("users")
+
+// boltKey encodes id (a decimal Uint64 string, as assigned by
+// b.NextSequence in Create) as a fixed-width big-endian byte string, so
+// bbolt's native lexicographic key order over the bucket coincides with
+// ascending numeric id order. Storing id's decimal bytes directly would
+// sort "10" before "2", diverging from MemoryUserStore's ascending
+// insertion-order traversal and from the List ordering contract.
+func boltKey(id string) ([]byte, error) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id %q: %w", id, err)
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, n)
+	return key, nil
+}
+
+// BoltUserStore is a UserStore backed by a bbolt database file, selected
+// via USER_STORE=bbolt when records need to survive a restart.
+type BoltUserStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltUserStore opens (creating if needed) a bbolt database at path,
+// migrating any records still under the pre-boltKey encoding (the raw
+// decimal User.Id bytes) to the fixed-width big-endian encoding so an
+// existing database keeps working after upgrading to it.
+func NewBoltUserStore(path string) (*BoltUserStore, error) {
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(usersBucket)
+		if err != nil {
+			return err
+		}
+		return migrateLegacyBoltKeys(b)
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &BoltUserStore{db: db}, nil
+}
+
+// isLegacyBoltKey reports whether k is a pre-boltKey bucket key: the raw
+// ASCII decimal bytes of a User.Id, rather than boltKey's 8-byte
+// big-endian encoding. Checking len(k) != 8 alone would misclassify a
+// legacy id with exactly 8 decimal digits (100,000,000-999,999,999) as
+// already migrated, so re-derive the new-format key for the id k decodes
+// to and compare - a real boltKey is only ever equal to its own encoding.
+func isLegacyBoltKey(k []byte) bool {
+	if _, err := strconv.ParseUint(string(k), 10, 64); err != nil {
+		return false
+	}
+	newKey, err := boltKey(string(k))
+	if err != nil {
+		return true
+	}
+	return !bytes.Equal(k, newKey)
+}
+
+// migrateLegacyBoltKeys re-keys any bucket entry still under the raw
+// decimal User.Id bytes a pre-boltKey version of this store used as its
+// key, to the fixed-width boltKey encoding, so List's ascending-id
+// ordering contract holds across the whole bucket instead of only the
+// records created after this fix shipped. It also advances the bucket's
+// NextSequence counter past the highest migrated id, so Create doesn't
+// hand out an id that collides with one of them.
+func migrateLegacyBoltKeys(b *bbolt.Bucket) error {
+	var legacy [][]byte
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if isLegacyBoltKey(k) {
+			legacy = append(legacy, append([]byte(nil), k...))
+		}
+	}
+
+	var maxID uint64
+	for _, k := range legacy {
+		data := append([]byte(nil), b.Get(k)...)
+
+		var u User
+		if err := json.Unmarshal(data, &u); err != nil {
+			return fmt.Errorf("migrate legacy user key %q: %w", k, err)
+		}
+		id, err := strconv.ParseUint(u.Id, 10, 64)
+		if err != nil {
+			return fmt.Errorf("migrate legacy user key %q: %w", k, err)
+		}
+		if id > maxID {
+			maxID = id
+		}
+
+		newKey, err := boltKey(u.Id)
+		if err != nil {
+			return fmt.Errorf("migrate legacy user key %q: %w", k, err)
+		}
+		if err := b.Put(newKey, data); err != nil {
+			return err
+		}
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	if len(legacy) > 0 && b.Sequence() < maxID {
+		return b.SetSequence(maxID)
+	}
+	return nil
+}
+
+func (s *BoltUserStore) Create(u User) (User, error) {
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(usersBucket)
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		u.Id = strconv.FormatUint(id, 10)
+
+		data, err := json.Marshal(u)
+		if err != nil {
+			return err
+		}
+		key, err := boltKey(u.Id)
+		if err != nil {
+			return err
+		}
+		return b.Put(key, data)
+	})
+	return u, err
+}
+
+func (s *BoltUserStore) Get(id string) (User, error) {
+	var u User
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		key, err := boltKey(id)
+		if err != nil {
+			return ErrUserNotFound
+		}
+		data := tx.Bucket(usersBucket).Get(key)
+		if data == nil {
+			return ErrUserNotFound
+		}
+		return json.Unmarshal(data, &u)
+	})
+	return u, err
+}
+
+func (s *BoltUserStore) Update(u User) (User, error) {
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(usersBucket)
+		key, err := boltKey(u.Id)
+		if err != nil {
+			return ErrUserNotFound
+		}
+		if b.Get(key) == nil {
+			return ErrUserNotFound
+		}
+
+		data, err := json.Marshal(u)
+		if err != nil {
+			return err
+		}
+		return b.Put(key, data)
+	})
+	return u, err
+}
+
+func (s *BoltUserStore) Delete(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(usersBucket)
+		key, err := boltKey(id)
+		if err != nil {
+			return ErrUserNotFound
+		}
+		if b.Get(key) == nil {
+			return ErrUserNotFound
+		}
+		return b.Delete(key)
+	})
+}
+
+func (s *BoltUserStore) List(pageSize int, pageToken string) ([]User, string, error) {
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+
+	var users []User
+	var nextPageToken string
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(usersBucket).Cursor()
+
+		var k, v []byte
+		if pageToken == "" {
+			k, v = c.First()
+		} else {
+			tokenKey, err := boltKey(pageToken)
+			if err != nil {
+				return fmt.Errorf("%w: %q", ErrInvalidPageToken, pageToken)
+			}
+			k, v = c.Seek(tokenKey)
+			// Seek lands on the first key >= tokenKey. If the token's own
+			// record is still present, that's tokenKey itself and the page
+			// starts after it. If it was deleted since the token was
+			// issued, Seek already landed on the next surviving record, so
+			// advancing again here would silently drop it.
+			if k != nil && bytes.Equal(k, tokenKey) {
+				k, v = c.Next()
+			}
+		}
+
+		for ; k != nil && len(users) < pageSize; k, v = c.Next() {
+			var u User
+			if err := json.Unmarshal(v, &u); err != nil {
+				return err
+			}
+			users = append(users, u)
+		}
+		if k != nil {
+			nextPageToken = users[len(users)-1].Id
+		}
+		return nil
+	})
+
+	return users, nextPageToken, err
+}
+
+// Close releases the underlying database file.
+func (s *BoltUserStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"go.etcd.io/bbolt"
+)
+
+// TestNewBoltUserStoreMigratesLegacyKeys covers opening a database written
+// by a version of BoltUserStore that keyed records by their raw decimal
+// User.Id bytes, before boltKey's fixed-width big-endian encoding existed.
+func TestNewBoltUserStoreMigratesLegacyKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.db")
+
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatalf("bbolt.Open: %v", err)
+	}
+	legacy := []User{
+		{Id: "2", Name: "two"},
+		{Id: "10", Name: "ten"},
+		{Id: "9", Name: "nine"},
+		// An 8 ASCII-digit id is the same length as a real boltKey, so
+		// migration must not mistake it for one already migrated.
+		{Id: "12345678", Name: "eight-digit"},
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(usersBucket)
+		if err != nil {
+			return err
+		}
+		for _, u := range legacy {
+			data, err := json.Marshal(u)
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte(u.Id), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seeding legacy keys: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("closing seeded db: %v", err)
+	}
+
+	store, err := NewBoltUserStore(path)
+	if err != nil {
+		t.Fatalf("NewBoltUserStore: %v", err)
+	}
+	defer store.Close()
+
+	for _, u := range legacy {
+		got, err := store.Get(u.Id)
+		if err != nil {
+			t.Fatalf("Get(%q) after migration: %v", u.Id, err)
+		}
+		if got.Name != u.Name {
+			t.Fatalf("Get(%q) = %+v, want name %q", u.Id, got, u.Name)
+		}
+	}
+
+	users, _, err := store.List(10, "")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var gotIDs []string
+	for _, u := range users {
+		gotIDs = append(gotIDs, u.Id)
+	}
+	want := []string{"2", "9", "10", "12345678"}
+	if len(gotIDs) != len(want) {
+		t.Fatalf("List ids = %v, want %v", gotIDs, want)
+	}
+	for i := range want {
+		if gotIDs[i] != want[i] {
+			t.Fatalf("migrated keys list in bbolt-lexicographic, not ascending-id, order: got %v, want %v", gotIDs, want)
+		}
+	}
+
+	// A user created post-migration must share the same ascending-id
+	// ordering as the migrated legacy ones.
+	next, err := store.Create(User{Name: "eleven"})
+	if err != nil {
+		t.Fatalf("Create after migration: %v", err)
+	}
+	if next.Id != "12345679" {
+		t.Fatalf("post-migration Create id = %q, want %q (NextSequence should continue past migrated ids)", next.Id, "12345679")
+	}
+}
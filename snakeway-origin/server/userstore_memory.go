@@ -0,0 +1,118 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// MemoryUserStore is an in-memory UserStore, safe for concurrent use. It
+// keeps records in a sync.Map for O(1) lookups and a RWMutex-guarded slice
+// of ids to give List a stable, deterministic order to paginate over.
+type MemoryUserStore struct {
+	records sync.Map // id -> User
+
+	mu  sync.RWMutex
+	ids []string
+
+	nextID atomic.Uint64
+}
+
+// NewMemoryUserStore returns an empty MemoryUserStore.
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{}
+}
+
+func (s *MemoryUserStore) Create(u User) (User, error) {
+	u.Id = strconv.FormatUint(s.nextID.Add(1), 10)
+	s.records.Store(u.Id, u)
+
+	s.mu.Lock()
+	s.ids = append(s.ids, u.Id)
+	s.mu.Unlock()
+
+	return u, nil
+}
+
+func (s *MemoryUserStore) Get(id string) (User, error) {
+	v, ok := s.records.Load(id)
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return v.(User), nil
+}
+
+func (s *MemoryUserStore) Update(u User) (User, error) {
+	if _, ok := s.records.Load(u.Id); !ok {
+		return User{}, ErrUserNotFound
+	}
+	s.records.Store(u.Id, u)
+	return u, nil
+}
+
+func (s *MemoryUserStore) Delete(id string) error {
+	if _, ok := s.records.LoadAndDelete(id); !ok {
+		return ErrUserNotFound
+	}
+
+	s.mu.Lock()
+	for i, existing := range s.ids {
+		if existing == id {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *MemoryUserStore) List(pageSize int, pageToken string) ([]User, string, error) {
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+
+	s.mu.RLock()
+	ids := append([]string(nil), s.ids...)
+	s.mu.RUnlock()
+
+	start := 0
+	if pageToken != "" {
+		// ids is in ascending insertion (== numeric id) order, so the start
+		// of the next page is the first id greater than pageToken - which
+		// also covers the case where the pageToken record itself has since
+		// been deleted, matching BoltUserStore's Seek-based semantics.
+		after, err := strconv.ParseUint(pageToken, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %q", ErrInvalidPageToken, pageToken)
+		}
+		start = len(ids)
+		for i, id := range ids {
+			n, err := strconv.ParseUint(id, 10, 64)
+			if err == nil && n > after {
+				start = i
+				break
+			}
+		}
+	}
+
+	end := start + pageSize
+	if end > len(ids) {
+		end = len(ids)
+	}
+
+	users := make([]User, 0, end-start)
+	for _, id := range ids[start:end] {
+		if v, ok := s.records.Load(id); ok {
+			users = append(users, v.(User))
+		}
+	}
+
+	var nextPageToken string
+	if end < len(ids) {
+		nextPageToken = ids[end-1]
+	}
+
+	return users, nextPageToken, nil
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// newStoresUnderTest returns one of each UserStore implementation, so
+// pagination tests below run against both under the same ordering
+// contract documented on UserStore.List.
+func newStoresUnderTest(t *testing.T) map[string]UserStore {
+	t.Helper()
+
+	bolt, err := NewBoltUserStore(filepath.Join(t.TempDir(), "users.db"))
+	if err != nil {
+		t.Fatalf("NewBoltUserStore: %v", err)
+	}
+	t.Cleanup(func() { _ = bolt.Close() })
+
+	return map[string]UserStore{
+		"memory": NewMemoryUserStore(),
+		"bbolt":  bolt,
+	}
+}
+
+func TestUserStoreListPagesInAscendingIDOrder(t *testing.T) {
+	for name, store := range newStoresUnderTest(t) {
+		t.Run(name, func(t *testing.T) {
+			const n = 12
+			for i := 0; i < n; i++ {
+				if _, err := store.Create(User{Name: "u"}); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+			}
+
+			var got []string
+			token := ""
+			for {
+				users, next, err := store.List(5, token)
+				if err != nil {
+					t.Fatalf("List(token=%q): %v", token, err)
+				}
+				for _, u := range users {
+					got = append(got, u.Id)
+				}
+				if next == "" {
+					break
+				}
+				token = next
+			}
+
+			want := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}
+			if len(got) != len(want) {
+				t.Fatalf("got %v ids, want %v", got, want)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("id at position %d = %q, want %q (got %v)", i, got[i], want[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestUserStoreListResumesAfterTokenRowDeleted(t *testing.T) {
+	for name, store := range newStoresUnderTest(t) {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 8; i++ {
+				if _, err := store.Create(User{Name: "u"}); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+			}
+
+			page1, token, err := store.List(5, "")
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if token != "5" {
+				t.Fatalf("page1 nextPageToken = %q, want %q", token, "5")
+			}
+
+			// The token's own record ("5") is deleted before it's used to
+			// fetch the next page - this is exactly the case that used to
+			// make BoltUserStore.List (Seek then unconditional Next) skip
+			// the first surviving record of the next page.
+			if err := store.Delete("5"); err != nil {
+				t.Fatalf("Delete(5): %v", err)
+			}
+
+			page2, _, err := store.List(5, token)
+			if err != nil {
+				t.Fatalf("List(token=%q) after deleting its row: %v", token, err)
+			}
+
+			var gotIDs []string
+			for _, u := range page2 {
+				gotIDs = append(gotIDs, u.Id)
+			}
+			want := []string{"6", "7", "8"}
+			if len(gotIDs) != len(want) {
+				t.Fatalf("page2 ids = %v, want %v", gotIDs, want)
+			}
+			for i := range want {
+				if gotIDs[i] != want[i] {
+					t.Fatalf("page2 ids = %v, want %v", gotIDs, want)
+				}
+			}
+			_ = page1
+		})
+	}
+}
+
+func TestUserStoreListInvalidPageToken(t *testing.T) {
+	for name, store := range newStoresUnderTest(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := store.Create(User{Name: "u"}); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			_, _, err := store.List(10, "not-a-number")
+			if !errors.Is(err, ErrInvalidPageToken) {
+				t.Fatalf("List with malformed token: err = %v, want ErrInvalidPageToken", err)
+			}
+		})
+	}
+}